@@ -0,0 +1,142 @@
+package fun
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// Bench is the entry point for benchmarking a function with fun's
+// fluent API, mirroring Test.
+func Bench(b *testing.B, fn interface{}) *FunBench {
+	fb := &FunBench{b: b}
+
+	if fn == nil {
+		fmt.Printf("Bench: 'fn' value passed to Bench is nil")
+		b.Fail()
+		return fb
+	}
+
+	val := reflect.ValueOf(fn)
+	typ := val.Type()
+	if typ.Kind() != reflect.Func {
+		fmt.Printf("Bench: 'fn' value passed to Bench isn't a func: %v\n", fn)
+		b.Fail()
+		return fb
+	}
+
+	fb.val = val
+	fb.typ = typ
+	fb.valid = true
+
+	numOut := typ.NumOut()
+	fb.errors = numOut > 0 && isError(typ.Out(numOut-1))
+
+	return fb
+}
+
+// FunBench contains the In method, and can be ignored as a type.
+type FunBench struct {
+	b      *testing.B
+	val    reflect.Value
+	typ    reflect.Type
+	valid  bool
+	errors bool
+}
+
+// In is where you pass in the arguments to call the benchmarked
+// function with, on every one of the b.N iterations.
+func (fb *FunBench) In(args ...interface{}) BenchCase {
+	return BenchCase{fb: fb, args: args}
+}
+
+// BenchCase contains the Do/OutCheck/SetBytes methods, and can be
+// ignored as a type.
+type BenchCase struct {
+	fb   *FunBench
+	args []interface{}
+}
+
+func (bc BenchCase) callArgs() []reflect.Value {
+	argVals := make([]reflect.Value, len(bc.args))
+	for i, arg := range bc.args {
+		argVals[i] = reflect.ValueOf(arg)
+	}
+	return argVals
+}
+
+// SetBytes is a passthrough for (*testing.B).SetBytes, for
+// throughput-oriented benchmarks. It should precede Do or OutCheck in
+// the chain.
+func (bc BenchCase) SetBytes(n int64) BenchCase {
+	bc.fb.b.SetBytes(n)
+	return bc
+}
+
+// Do runs the benchmarked function b.N times with the arguments given
+// to In. The args are built into []reflect.Value once and reused across
+// iterations, and the timer is reset immediately beforehand and stopped
+// immediately after, so that arg setup isn't counted against the
+// benchmark.
+func (bc BenchCase) Do() {
+	if !bc.fb.valid {
+		return
+	}
+
+	b := bc.fb.b
+	argVals := bc.callArgs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bc.fb.val.Call(argVals)
+	}
+	b.StopTimer()
+}
+
+// OutCheck is like Do, but also verifies the function's result against
+// checker, using args as the checker's comparison arguments. The check
+// only runs on the first iteration, so it doesn't skew the benchmark;
+// a failing check stops the benchmark immediately via b.Fatalf.
+func (bc BenchCase) OutCheck(checker Checker, args ...interface{}) {
+	if !bc.fb.valid {
+		return
+	}
+
+	b := bc.fb.b
+	argVals := bc.callArgs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		resVals := bc.fb.val.Call(argVals)
+		if i == 0 {
+			bc.checkResult(resVals, checker, args)
+		}
+	}
+	b.StopTimer()
+}
+
+func (bc BenchCase) checkResult(resVals []reflect.Value, checker Checker, args []interface{}) {
+	b := bc.fb.b
+
+	realResults := make([]interface{}, len(resVals))
+	for i, resVal := range resVals {
+		realResults[i] = resVal.Interface()
+	}
+
+	if bc.fb.errors && len(realResults) > 0 {
+		last := realResults[len(realResults)-1]
+		if last != nil {
+			b.Fatalf("Case: unexpected error: %v", last)
+		}
+		realResults = realResults[:len(realResults)-1]
+	}
+
+	if len(realResults) != 1 {
+		b.Fatalf("OutCheck requires exactly one non-error result, but got %d", len(realResults))
+	}
+
+	if err := checker.Check(realResults[0], args); err != nil {
+		name, _ := checker.Info()
+		b.Fatalf("%s check failed: %v", name, err)
+	}
+}
@@ -0,0 +1,17 @@
+package fun
+
+import "testing"
+
+func addThree(a, b, c int) int { return a + b + c }
+
+func BenchmarkAddThree(b *testing.B) {
+	Bench(b, addThree).In(1, 2, 3).Do()
+}
+
+func BenchmarkAddThreeChecked(b *testing.B) {
+	Bench(b, addThree).In(1, 2, 3).OutCheck(Equals, 6)
+}
+
+func BenchmarkSumUnder10(b *testing.B) {
+	Bench(b, sumUnder10).In(1, 2, 3).SetBytes(1).Do()
+}
@@ -0,0 +1,150 @@
+package fun
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Cases expands rows, a slice of structs describing one test case each,
+// into individual In(...).Out/Err/Panic(...) calls, each run as its own
+// subtest (see Case.Name, Case.Parallel, and the subtest support in
+// withSubtest).
+//
+// rows may use the explicit shape
+//
+//	struct {
+//		In    []interface{}
+//		Out   []interface{}
+//		Err   error
+//		Panic interface{}
+//		Name  string
+//	}
+//
+// or, as shorthand, a row struct whose other fields are typed to match
+// the tested function's argument and non-error return types directly,
+// e.g.
+//
+//	type row struct{ A, B, Want int; Err error }
+//
+// for a func(a, b int) (int, error): A and B become the In() arguments,
+// and Want becomes the expected Out() value. Exported fields named "In",
+// "Out", "Err", "Panic", and "Name" are always reserved for the explicit
+// shape above; any other exported field is assigned to an argument or
+// return value by position, arguments first.
+//
+// For a given row, a non-nil Panic value runs Panic(panic), else a
+// non-nil Err runs Err(err), else Out(out...) runs with the row's Out
+// field (or its positional equivalent).
+func (ft *FunTest) Cases(rows interface{}) {
+	if !ft.valid {
+		return
+	}
+
+	v := reflect.ValueOf(rows)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		fmt.Printf("Cases: rows must be a slice or array of structs, got %T\n", rows)
+		ft.t.Fail()
+		return
+	}
+
+	for i := 0; i < v.Len(); i++ {
+		ft.runRow(v.Index(i))
+	}
+}
+
+func (ft *FunTest) runRow(row reflect.Value) {
+	typ := row.Type()
+	if typ.Kind() != reflect.Struct {
+		fmt.Printf("Cases: row must be a struct, got %s\n", typ.Kind())
+		ft.t.Fail()
+		return
+	}
+
+	var in, out []interface{}
+	haveIn, haveOut := false, false
+	var errVal, panicVal interface{}
+	haveErr, havePanic := false, false
+	name := ""
+
+	var extra []reflect.Value
+
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		if f.PkgPath != "" {
+			continue // unexported
+		}
+		fv := row.Field(i)
+
+		switch f.Name {
+		case "In":
+			s, ok := fv.Interface().([]interface{})
+			if !ok {
+				fmt.Printf("Cases: row %s field In must be []interface{}, got %s\n", typ, f.Type)
+				ft.t.Fail()
+				return
+			}
+			in, haveIn = s, true
+			continue
+		case "Out":
+			s, ok := fv.Interface().([]interface{})
+			if !ok {
+				fmt.Printf("Cases: row %s field Out must be []interface{}, got %s\n", typ, f.Type)
+				ft.t.Fail()
+				return
+			}
+			out, haveOut = s, true
+			continue
+		case "Err":
+			errVal, haveErr = fv.Interface(), true
+			continue
+		case "Panic":
+			panicVal, havePanic = fv.Interface(), true
+			continue
+		case "Name":
+			s, ok := fv.Interface().(string)
+			if !ok {
+				fmt.Printf("Cases: row %s field Name must be a string, got %s\n", typ, f.Type)
+				ft.t.Fail()
+				return
+			}
+			name = s
+			continue
+		}
+		extra = append(extra, fv)
+	}
+
+	if !haveIn {
+		numIn := ft.typ.NumIn()
+		if len(extra) < numIn {
+			fmt.Printf("Cases: row %s has too few fields for %d argument(s)\n", typ, numIn)
+			ft.t.Fail()
+			return
+		}
+		in = make([]interface{}, numIn)
+		for i := 0; i < numIn; i++ {
+			in[i] = extra[i].Interface()
+		}
+		extra = extra[numIn:]
+	}
+
+	if !haveOut {
+		out = make([]interface{}, len(extra))
+		for i, fv := range extra {
+			out[i] = fv.Interface()
+		}
+	}
+
+	c := ft.In(in...)
+	if name != "" {
+		c = c.Name(name)
+	}
+
+	switch {
+	case havePanic && panicVal != nil:
+		c.Panic(panicVal)
+	case haveErr && errVal != nil:
+		c.Err(errVal)
+	default:
+		c.Out(out...)
+	}
+}
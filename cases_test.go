@@ -0,0 +1,73 @@
+package fun
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCasesExplicit(t *testing.T) {
+	tf := &testFailer{}
+	f := test(tf, sumUnder10)
+
+	type row struct {
+		Name  string
+		In    []interface{}
+		Out   []interface{}
+		Err   error
+		Panic interface{}
+	}
+
+	f.Cases([]row{
+		{Name: "small sum", In: []interface{}{1, 2}, Out: []interface{}{3}},
+		{Name: "too big", In: []interface{}{5, 5}, Err: errors.New("sum should be less than 10")},
+		{Name: "negative panics", In: []interface{}{-1, 2}, Panic: "-1 is negative"},
+	})
+	if tf.failed {
+		t.Error("explicit Cases table failed")
+	}
+}
+
+func addPair(a, b int) (int, error) {
+	if a < 0 || b < 0 {
+		return 0, errors.New("negative operand")
+	}
+	return a + b, nil
+}
+
+func TestCasesTyped(t *testing.T) {
+	tf := &testFailer{}
+	f := test(tf, addPair)
+
+	type row struct {
+		A, B, Want int
+		Err        error
+	}
+
+	f.Cases([]row{
+		{A: 1, B: 2, Want: 3},
+		{A: 4, B: 5, Want: 9},
+		{A: -1, B: 2, Err: errors.New("negative operand")},
+	})
+	if tf.failed {
+		t.Error("typed Cases table failed")
+	}
+}
+
+func TestCasesReservedFieldWrongType(t *testing.T) {
+	tf := &testFailer{}
+	f := test(tf, addPair)
+
+	// Out is a reserved name, but here it's typed as an int rather than
+	// []interface{}; it must be rejected rather than silently falling
+	// back to a positional argument/result.
+	type row struct {
+		A, Out, B int
+	}
+
+	f.Cases([]row{
+		{A: 1, Out: 3, B: 2},
+	})
+	if !tf.failed {
+		t.Error("row with a mistyped reserved field should have failed")
+	}
+}
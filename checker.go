@@ -0,0 +1,437 @@
+package fun
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// Checker compares a value produced by a test case against expected
+// arguments, in place of fun's default reflect.DeepEqual comparison.
+//
+// Check is given the got value and the args passed alongside the checker
+// (to Check, OutCheck, or PanicCheck), and should return a non-nil error
+// describing the mismatch if the comparison fails.
+//
+// Info returns a name for the checker and the names of its expected
+// arguments, used when fun needs to describe a checker in a failure
+// message.
+type Checker interface {
+	Check(got interface{}, args []interface{}) error
+	Info() (name string, argNames []string)
+}
+
+type checkerFunc struct {
+	name     string
+	argNames []string
+	check    func(got interface{}, args []interface{}) error
+}
+
+func (c *checkerFunc) Check(got interface{}, args []interface{}) error {
+	return c.check(got, args)
+}
+
+func (c *checkerFunc) Info() (string, []string) {
+	return c.name, c.argNames
+}
+
+func wantArg(args []interface{}, i int) interface{} {
+	if i >= len(args) {
+		return nil
+	}
+	return args[i]
+}
+
+// Equals checks that got == want, using Go's == operator. It returns an
+// error (rather than panicking) if got and want aren't comparable; use
+// DeepEquals for slices, maps, and structs that contain them.
+var Equals Checker = &checkerFunc{
+	name:     "Equals",
+	argNames: []string{"want"},
+	check: func(got interface{}, args []interface{}) (err error) {
+		want := wantArg(args, 0)
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("uncomparable values, use DeepEquals: %v", r)
+			}
+		}()
+		if got != want {
+			return fmt.Errorf("got %#v, want %#v", got, want)
+		}
+		return nil
+	},
+}
+
+// DeepEquals checks that got and want are equal according to
+// reflect.DeepEqual.
+var DeepEquals Checker = &checkerFunc{
+	name:     "DeepEquals",
+	argNames: []string{"want"},
+	check: func(got interface{}, args []interface{}) error {
+		want := wantArg(args, 0)
+		if !reflect.DeepEqual(got, want) {
+			return fmt.Errorf("got %#v, want %#v", got, want)
+		}
+		return nil
+	},
+}
+
+// IsNil checks that got is nil, or a nil pointer, slice, map, channel,
+// func, or interface value.
+var IsNil Checker = &checkerFunc{
+	name: "IsNil",
+	check: func(got interface{}, args []interface{}) error {
+		if got == nil {
+			return nil
+		}
+		v := reflect.ValueOf(got)
+		switch v.Kind() {
+		case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice:
+			if v.IsNil() {
+				return nil
+			}
+		}
+		return fmt.Errorf("got %#v, want nil", got)
+	},
+}
+
+// HasLen checks that got has the given length, as reported by len().
+var HasLen Checker = &checkerFunc{
+	name:     "HasLen",
+	argNames: []string{"n"},
+	check: func(got interface{}, args []interface{}) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("got %#v, which has no length", got)
+			}
+		}()
+		n, _ := wantArg(args, 0).(int)
+		l := reflect.ValueOf(got).Len()
+		if l != n {
+			return fmt.Errorf("got length %d, want %d", l, n)
+		}
+		return nil
+	},
+}
+
+// Matches checks that got, or its string representation, matches the
+// regular expression given as the checker's argument. Unlike
+// regexp.MatchString, the match is anchored to the whole of the string.
+var Matches Checker = &checkerFunc{
+	name:     "Matches",
+	argNames: []string{"regexp"},
+	check: func(got interface{}, args []interface{}) error {
+		pattern, _ := wantArg(args, 0).(string)
+		s, ok := got.(string)
+		if !ok {
+			s = fmt.Sprint(got)
+		}
+		return matchString(s, pattern)
+	},
+}
+
+// PanicMatches matches just as Matches does (got is formatted with
+// fmt.Sprint, then matched against the whole regular expression), but
+// reports a failure as a mismatched panic value, for use with PanicCheck.
+var PanicMatches Checker = &checkerFunc{
+	name:     "PanicMatches",
+	argNames: []string{"regexp"},
+	check: func(got interface{}, args []interface{}) error {
+		pattern, _ := wantArg(args, 0).(string)
+		if err := matchString(fmt.Sprint(got), pattern); err != nil {
+			return fmt.Errorf("panic value %v", err)
+		}
+		return nil
+	},
+}
+
+// ErrorMatches checks that got is a non-nil error whose Error() message
+// matches the regular expression given as the checker's argument.
+var ErrorMatches Checker = &checkerFunc{
+	name:     "ErrorMatches",
+	argNames: []string{"regexp"},
+	check: func(got interface{}, args []interface{}) error {
+		pattern, _ := wantArg(args, 0).(string)
+		err, ok := got.(error)
+		if !ok || err == nil {
+			return fmt.Errorf("got %#v, which isn't a non-nil error", got)
+		}
+		if merr := matchString(err.Error(), pattern); merr != nil {
+			return fmt.Errorf("error %v", merr)
+		}
+		return nil
+	},
+}
+
+// ErrorIs checks that got is an error for which errors.Is(got, target)
+// reports true, where target is the checker's argument.
+var ErrorIs Checker = &checkerFunc{
+	name:     "ErrorIs",
+	argNames: []string{"target"},
+	check: func(got interface{}, args []interface{}) error {
+		target, _ := wantArg(args, 0).(error)
+		err, _ := got.(error)
+		if !errors.Is(err, target) {
+			return fmt.Errorf("got error %#v, which doesn't match target %#v", got, target)
+		}
+		return nil
+	},
+}
+
+// ErrorAs checks that got is an error for which errors.As(got, target)
+// succeeds, where target is a non-nil pointer passed as the checker's
+// argument. On success target is populated, just as with errors.As.
+var ErrorAs Checker = &checkerFunc{
+	name:     "ErrorAs",
+	argNames: []string{"target"},
+	check: func(got interface{}, args []interface{}) error {
+		err, _ := got.(error)
+		target := wantArg(args, 0)
+		if target == nil {
+			return fmt.Errorf("ErrorAs needs a non-nil target pointer")
+		}
+		if !errors.As(err, target) {
+			return fmt.Errorf("got error %#v, which can't be assigned to %T", got, target)
+		}
+		return nil
+	},
+}
+
+// Contains checks that got contains the checker's argument: as a
+// substring if got is a string, or as an element if got is a slice,
+// array, or map.
+var Contains Checker = &checkerFunc{
+	name:     "Contains",
+	argNames: []string{"want"},
+	check: func(got interface{}, args []interface{}) error {
+		want := wantArg(args, 0)
+		if s, ok := got.(string); ok {
+			substr, _ := want.(string)
+			if !strings.Contains(s, substr) {
+				return fmt.Errorf("%q doesn't contain %q", s, substr)
+			}
+			return nil
+		}
+		v := reflect.ValueOf(got)
+		switch v.Kind() {
+		case reflect.Slice, reflect.Array:
+			for i := 0; i < v.Len(); i++ {
+				if reflect.DeepEqual(v.Index(i).Interface(), want) {
+					return nil
+				}
+			}
+		case reflect.Map:
+			for _, k := range v.MapKeys() {
+				if reflect.DeepEqual(v.MapIndex(k).Interface(), want) {
+					return nil
+				}
+			}
+		default:
+			return fmt.Errorf("got %#v, which fun.Contains doesn't know how to search", got)
+		}
+		return fmt.Errorf("%#v doesn't contain %#v", got, want)
+	},
+}
+
+// Approximately returns a Checker that checks a numeric result is within
+// tolerance of the expected value.
+func Approximately(tolerance float64) Checker {
+	return &checkerFunc{
+		name:     "Approximately",
+		argNames: []string{"want"},
+		check: func(got interface{}, args []interface{}) error {
+			g, gok := toFloat64(got)
+			w, wok := toFloat64(wantArg(args, 0))
+			if !gok || !wok {
+				return fmt.Errorf("got %#v, want %#v, which aren't both numbers", got, wantArg(args, 0))
+			}
+			if math.Abs(g-w) > tolerance {
+				return fmt.Errorf("got %v, want %v (+/- %v)", g, w, tolerance)
+			}
+			return nil
+		},
+	}
+}
+
+func toFloat64(v interface{}) (float64, bool) {
+	if v == nil {
+		return 0, false
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(rv.Uint()), true
+	}
+	return 0, false
+}
+
+func matchString(s, pattern string) error {
+	matched, err := regexp.MatchString("^(?:"+pattern+")$", s)
+	if err != nil {
+		return fmt.Errorf("bad regexp %q: %v", pattern, err)
+	}
+	if !matched {
+		return fmt.Errorf("%q doesn't match %q", s, pattern)
+	}
+	return nil
+}
+
+// errContains backs Case.ErrContains.
+var errContains Checker = &checkerFunc{
+	name:     "ErrContains",
+	argNames: []string{"substr"},
+	check: func(got interface{}, args []interface{}) error {
+		err, ok := got.(error)
+		if !ok || err == nil {
+			return fmt.Errorf("got %#v, which isn't a non-nil error", got)
+		}
+		substr, _ := wantArg(args, 0).(string)
+		if !strings.Contains(err.Error(), substr) {
+			return fmt.Errorf("error message %q doesn't contain %q", err.Error(), substr)
+		}
+		return nil
+	},
+}
+
+func (c Case) printCheckFail(checker Checker, err error) {
+	name, _ := checker.Info()
+	c.printf("%s check failed: %v\n", name, err)
+}
+
+// Check is like Err, but delegates the comparison of the final return
+// value to checker instead of Err's errors.Is/reflect.DeepEqual
+// matching; a nil error is passed to checker too. It's only valid if the tested
+// function's final return value is an error.
+func (c Case) Check(checker Checker, args ...interface{}) (ret *FunTest) {
+	ret = c.ft
+
+	if !c.ft.valid {
+		return
+	}
+
+	c, args = c.extractComment(args)
+
+	c.withSubtest(func(tb failer) {
+		if !c.ft.errors {
+			c.println("Check() called with a func that doesn't error")
+			tb.Fail()
+			return
+		}
+
+		defer func() {
+			if r := recover(); r != nil {
+				c.println("expected error, but panic occured:", r)
+				tb.Fail()
+			}
+		}()
+
+		resVals := c.ft.val.Call(c.callArgs())
+
+		if len(resVals) == 0 {
+			c.println("expected an error, but no values were returned")
+			tb.Fail()
+			return
+		}
+
+		last := resVals[len(resVals)-1].Interface()
+		if err := checker.Check(last, args); err != nil {
+			c.printCheckFail(checker, err)
+			tb.Fail()
+		}
+	})
+
+	return
+}
+
+// OutCheck is like Out, but delegates the comparison to checker instead
+// of always using reflect.DeepEqual. It requires the tested function to
+// have exactly one non-error return value.
+func (c Case) OutCheck(checker Checker, args ...interface{}) (ret *FunTest) {
+	ret = c.ft
+
+	if !c.ft.valid {
+		return
+	}
+
+	c, args = c.extractComment(args)
+
+	c.withSubtest(func(tb failer) {
+		defer func() {
+			if r := recover(); r != nil {
+				c.println("panic: ", r)
+				tb.Fail()
+			}
+		}()
+
+		resVals := c.ft.val.Call(c.callArgs())
+		realResults := make([]interface{}, len(resVals))
+		for i, resVal := range resVals {
+			realResults[i] = resVal.Interface()
+		}
+
+		if c.ft.errors && len(realResults) > 0 {
+			last := realResults[len(realResults)-1]
+			if last != nil {
+				c.println(last)
+				tb.Fail()
+				return
+			}
+			realResults = realResults[:len(realResults)-1]
+		}
+
+		if len(realResults) != 1 {
+			c.printf("OutCheck requires exactly one non-error result, but got %d\n", len(realResults))
+			tb.Fail()
+			return
+		}
+
+		if err := checker.Check(realResults[0], args); err != nil {
+			c.printCheckFail(checker, err)
+			tb.Fail()
+		}
+	})
+
+	return
+}
+
+// PanicCheck is like Panic, but delegates the comparison of the
+// recovered value to checker instead of always using reflect.DeepEqual.
+func (c Case) PanicCheck(checker Checker, args ...interface{}) (ret *FunTest) {
+	ret = c.ft
+
+	if !c.ft.valid {
+		return
+	}
+
+	c, args = c.extractComment(args)
+
+	c.withSubtest(func(tb failer) {
+		didPanic := true
+
+		defer func() {
+			if !didPanic {
+				return
+			}
+			r := recover()
+			if err := checker.Check(r, args); err != nil {
+				c.printCheckFail(checker, err)
+				tb.Fail()
+			}
+		}()
+
+		c.ft.val.Call(c.callArgs())
+
+		didPanic = false
+		c.println("function was called successfully, expected to panic")
+		tb.Fail()
+	})
+
+	return
+}
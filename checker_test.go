@@ -0,0 +1,110 @@
+package fun
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+)
+
+func divide(a, b float64) (float64, error) {
+	if b == 0 {
+		panic("divide by zero")
+	}
+	if a < 0 {
+		return 0, fmt.Errorf("wrapped: %w", io.EOF)
+	}
+	return a / b, nil
+}
+
+func TestCheckers(t *testing.T) {
+	tf := &testFailer{}
+	f := test(tf, divide)
+
+	passCases := []func(){
+		func() { f.In(1.0, 3.0).OutCheck(Approximately(1e-9), 0.333333333) },
+		func() { f.In(6.0, 2.0).OutCheck(Equals, 3.0) },
+		func() { f.In(6.0, 2.0).OutCheck(DeepEquals, 3.0) },
+		func() { f.In(-1.0, 2.0).Check(ErrorIs, io.EOF) },
+		func() { f.In(-1.0, 2.0).Check(ErrorMatches, "wrapped: .*") },
+		func() { f.In(-1.0, 2.0).ErrContains("EOF") },
+		func() { f.In(6.0, 0.0).PanicCheck(Matches, "divide by .*") },
+		func() { f.In(6.0, 0.0).PanicCheck(PanicMatches, "divide by zero") },
+	}
+	for i, c := range passCases {
+		c()
+		if tf.failed {
+			t.Errorf("pass case %d failed", i+1)
+			tf.failed = false
+		}
+	}
+
+	failCases := []func(){
+		func() { f.In(1.0, 3.0).OutCheck(Approximately(1e-9), 0.5) },
+		func() { f.In(6.0, 2.0).OutCheck(Equals, 4.0) },
+		func() { f.In(-1.0, 2.0).Check(ErrorIs, errors.New("other")) },
+		func() { f.In(-1.0, 2.0).Check(ErrorMatches, "nope") },
+		func() { f.In(6.0, 0.0).PanicCheck(Matches, "nope") },
+	}
+	for i, c := range failCases {
+		c()
+		if !tf.failed {
+			t.Errorf("fail case %d didn't fail", i+1)
+		}
+		tf.failed = false
+	}
+}
+
+func TestIsNilAndHasLen(t *testing.T) {
+	var nilSlice []int
+	if err := IsNil.Check(nilSlice, nil); err != nil {
+		t.Errorf("IsNil.Check(nil slice) = %v, want nil", err)
+	}
+	if err := IsNil.Check([]int{1}, nil); err == nil {
+		t.Error("IsNil.Check([]int{1}) = nil, want error")
+	}
+
+	if err := HasLen.Check([]int{1, 2, 3}, []interface{}{3}); err != nil {
+		t.Errorf("HasLen.Check = %v, want nil", err)
+	}
+	if err := HasLen.Check([]int{1, 2, 3}, []interface{}{2}); err == nil {
+		t.Error("HasLen.Check = nil, want error")
+	}
+}
+
+type wrappedError struct{ msg string }
+
+func (e *wrappedError) Error() string { return e.msg }
+
+func TestErrorAs(t *testing.T) {
+	wrapped := fmt.Errorf("outer: %w", &wrappedError{msg: "inner"})
+
+	var target *wrappedError
+	if err := ErrorAs.Check(wrapped, []interface{}{&target}); err != nil {
+		t.Errorf("ErrorAs.Check = %v, want nil", err)
+	}
+	if target == nil || target.msg != "inner" {
+		t.Errorf("target = %+v, want a populated *wrappedError", target)
+	}
+
+	var other *wrappedError
+	if err := ErrorAs.Check(errors.New("plain"), []interface{}{&other}); err == nil {
+		t.Error("ErrorAs.Check(unrelated error) = nil, want error")
+	}
+
+	if err := ErrorAs.Check(wrapped, nil); err == nil {
+		t.Error("ErrorAs.Check(nil target) = nil, want error")
+	}
+}
+
+func TestContains(t *testing.T) {
+	if err := Contains.Check("hello world", []interface{}{"wor"}); err != nil {
+		t.Errorf("Contains.Check(string) = %v, want nil", err)
+	}
+	if err := Contains.Check([]int{1, 2, 3}, []interface{}{2}); err != nil {
+		t.Errorf("Contains.Check(slice) = %v, want nil", err)
+	}
+	if err := Contains.Check([]int{1, 2, 3}, []interface{}{4}); err == nil {
+		t.Error("Contains.Check(slice) = nil, want error")
+	}
+}
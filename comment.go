@@ -0,0 +1,53 @@
+package fun
+
+import "fmt"
+
+// Comment is a lazily-formatted note attached to a case, printed
+// alongside any failure message for that case, in the style of
+// quicktest's Commentf. Build one with Commentf.
+type Comment struct {
+	format string
+	args   []interface{}
+}
+
+// Commentf builds a Comment. It's formatted with fmt.Sprintf only if the
+// case it's attached to fails, so callers can pass expensive formatters
+// cheaply. Attach one with Case.Comment, or pass it as the trailing
+// argument to Out, Err, or Panic.
+func Commentf(format string, args ...interface{}) Comment {
+	return Comment{format: format, args: args}
+}
+
+func (c Comment) String() string {
+	return fmt.Sprintf(c.format, c.args...)
+}
+
+// Comment attaches a lazily-formatted note to this case, printed
+// alongside any failure message for it. This is equivalent to passing
+// fun.Commentf(format, args...) as the trailing argument to Out, Err, or
+// Panic.
+func (c Case) Comment(format string, args ...interface{}) Case {
+	cm := Commentf(format, args...)
+	c.comment = &cm
+	return c
+}
+
+// extractComment returns c with a trailing Comment popped off of args,
+// if there is one, and the args with it removed. This lets Out, Err, and
+// Panic recognize a Commentf passed as their final argument.
+func (c Case) extractComment(args []interface{}) (Case, []interface{}) {
+	if len(args) == 0 {
+		return c, args
+	}
+	if cm, ok := args[len(args)-1].(Comment); ok {
+		c.comment = &cm
+		return c, args[:len(args)-1]
+	}
+	return c, args
+}
+
+func (c Case) printComment() {
+	if c.comment != nil {
+		fmt.Printf("\tcomment: %s\n", c.comment.String())
+	}
+}
@@ -0,0 +1,39 @@
+package fun
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCommentf(t *testing.T) {
+	if got, want := Commentf("iter=%d seed=%#x", 7, 0xdeadbeef).String(), "iter=7 seed=0xdeadbeef"; got != want {
+		t.Errorf("Commentf(...).String() = %q, want %q", got, want)
+	}
+}
+
+func TestCaseComment(t *testing.T) {
+	tf := &testFailer{}
+	f := test(tf, sumUnder10)
+
+	passCases := []func(){
+		func() { f.In(1, 2).Comment("checking a basic sum").Out(3) },
+		func() { f.In(1, 2).Out(3, Commentf("trailing comment form")) },
+		func() { f.In(5, 5).Err(errors.New("sum should be less than 10"), Commentf("iter=%d", 1)) },
+		func() { f.In(-1, 2, 3).Panic("-1 is negative", Commentf("iter=%d", 2)) },
+	}
+	for i, c := range passCases {
+		c()
+		if tf.failed {
+			t.Errorf("pass case %d failed", i+1)
+			tf.failed = false
+		}
+	}
+
+	// A comment shouldn't change whether a case passes or fails, only
+	// what gets printed when it does.
+	f.In(1, 2).Comment("wrong expectation").Out(4)
+	if !tf.failed {
+		t.Error("fail case with comment didn't fail")
+	}
+	tf.failed = false
+}
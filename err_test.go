@@ -0,0 +1,103 @@
+package fun
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+var errSentinel = errors.New("sentinel failure")
+
+type wrappedStringError struct {
+	s string
+}
+
+func (e *wrappedStringError) Error() string { return e.s }
+
+func wrapError(fail bool, target error) error {
+	if !fail {
+		return nil
+	}
+	return fmt.Errorf("doing the thing: %w", target)
+}
+
+func plainError(msg string) error {
+	if msg == "" {
+		return nil
+	}
+	return errors.New(msg)
+}
+
+func TestErrWrapped(t *testing.T) {
+	tf := &testFailer{}
+	f := test(tf, wrapError)
+
+	passCases := []func(){
+		func() { f.In(true, errSentinel).Err(errSentinel) },
+		func() { f.In(true, errSentinel).Err() },
+		func() { f.In(false, errSentinel).Err(nil) },
+		func() { f.In(true, errSentinel).ErrContains("doing the thing") },
+	}
+	for i, c := range passCases {
+		c()
+		if tf.failed {
+			t.Errorf("pass case %d failed", i+1)
+			tf.failed = false
+		}
+	}
+
+	failCases := []func(){
+		func() { f.In(true, errSentinel).Err(errors.New("unrelated")) },
+		func() { f.In(true, errSentinel).ErrContains("nope") },
+	}
+	for i, c := range failCases {
+		c()
+		if !tf.failed {
+			t.Errorf("fail case %d didn't fail", i+1)
+		}
+		tf.failed = false
+	}
+
+	var target *wrappedStringError
+	wrapWithType := func(fail bool) error {
+		if !fail {
+			return nil
+		}
+		return fmt.Errorf("wrapping: %w", &wrappedStringError{s: "inner"})
+	}
+	g := test(tf, wrapWithType)
+	g.In(true).ErrAs(&target)
+	if tf.failed {
+		t.Error("ErrAs pass case failed")
+		tf.failed = false
+	}
+	if target == nil || target.s != "inner" {
+		t.Errorf("ErrAs didn't populate target, got %v", target)
+	}
+
+	g.In(false).ErrAs(&target)
+	if !tf.failed {
+		t.Error("ErrAs fail case didn't fail")
+	}
+	tf.failed = false
+}
+
+// TestErrDeepEqualFallback checks that Err still matches sentinel errors
+// that are equal by value but not identity, as it did before errors.Is
+// support was added, as long as the tested function doesn't wrap them.
+func TestErrDeepEqualFallback(t *testing.T) {
+	tf := &testFailer{}
+	p := test(tf, plainError)
+
+	p.In("sum should be less than 10").Err(errors.New("sum should be less than 10"))
+	if tf.failed {
+		t.Error("DeepEqual fallback pass case failed")
+		tf.failed = false
+	}
+
+	p.In("sum should be less than 10").Err(errors.New("different message"))
+	if !tf.failed {
+		t.Error("DeepEqual fallback fail case didn't fail")
+	}
+	tf.failed = false
+}
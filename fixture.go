@@ -0,0 +1,111 @@
+package fun
+
+// SetUp registers fn to run before every In(...).Out/Err/Panic (or
+// Check/OutCheck/PanicCheck) call on ft, wrapped in recover so a
+// panicking fixture fails only the current case rather than aborting
+// the rest of the chain. fn receives ft, so a fixture can read or write
+// ft.Context to share state with the case that follows (for example,
+// seeding a temp directory whose path later cases use as an In argument).
+func (ft *FunTest) SetUp(fn func(ft *FunTest)) *FunTest {
+	ft.setUp = fn
+	return ft
+}
+
+// TearDown registers fn to run after every In(...).Out/Err/Panic call on
+// ft, with the same panic-recovery behavior as SetUp.
+func (ft *FunTest) TearDown(fn func(ft *FunTest)) *FunTest {
+	ft.tearDown = fn
+	return ft
+}
+
+// SetUpSuite registers fn to run once, before the first case, in the
+// style of gocheck's suite fixtures.
+func (ft *FunTest) SetUpSuite(fn func(ft *FunTest)) *FunTest {
+	ft.setUpSuite = fn
+	return ft
+}
+
+// TearDownSuite registers fn to run once, after the last case. This is
+// scheduled with (*testing.T).Cleanup, so it only fires when ft's
+// failer is a real *testing.T; with any other failer, call fn yourself
+// once you're done with ft.
+func (ft *FunTest) TearDownSuite(fn func(ft *FunTest)) *FunTest {
+	ft.tearDownSuite = fn
+	return ft
+}
+
+// contextArg is the sentinel FromContext wraps its fn in. callArgs
+// recognizes it and swaps it out for fn's result immediately before the
+// call, once SetUp/SetUpSuite have had a chance to populate ft.Context.
+type contextArg struct {
+	fn func(ctx interface{}) interface{}
+}
+
+// FromContext defers resolution of an In(...) argument until the case
+// actually calls the tested function, which happens after SetUp and
+// SetUpSuite have run. fn receives ft.Context and returns the real
+// argument value, so a fixture that seeds ft.Context (for example with a
+// temp directory's path) can thread that value into the case that
+// follows it, instead of the value In(ft.Context) would capture when In
+// is called, before that case's SetUp has run:
+//
+//	f.SetUp(func(ft *FunTest) { ft.Context = makeTempDir() })
+//	f.In(fun.FromContext(func(ctx interface{}) interface{} { return ctx })).Out(...)
+func FromContext(fn func(ctx interface{}) interface{}) interface{} {
+	return contextArg{fn: fn}
+}
+
+// cleanuper is implemented by *testing.T and *testing.B, and lets
+// runSetUpSuite schedule TearDownSuite without fun depending on which
+// one ft was built with.
+type cleanuper interface {
+	Cleanup(func())
+}
+
+// runSetUpSuite runs the registered SetUpSuite fixture, and schedules
+// the TearDownSuite fixture, the first time a case runs.
+func (ft *FunTest) runSetUpSuite() {
+	ft.suiteOnce.Do(func() {
+		if ft.setUpSuite != nil {
+			ft.setUpSuite(ft)
+		}
+		if ft.tearDownSuite != nil {
+			if c, ok := ft.t.(cleanuper); ok {
+				c.Cleanup(func() { ft.tearDownSuite(ft) })
+			}
+		}
+	})
+}
+
+// runSetUp runs ft's SetUp fixture, if any, and reports whether the
+// case should proceed; it returns false if the fixture panicked.
+func (c Case) runSetUp(tb failer) (ok bool) {
+	if c.ft.setUp == nil {
+		return true
+	}
+	ok = true
+	defer func() {
+		if r := recover(); r != nil {
+			c.printf("SetUp panicked: %v\n", r)
+			tb.Fail()
+			ok = false
+		}
+	}()
+	c.ft.setUp(c.ft)
+	return ok
+}
+
+// runTearDown runs ft's TearDown fixture, if any, recovering a panic so
+// it fails only the current case.
+func (c Case) runTearDown(tb failer) {
+	if c.ft.tearDown == nil {
+		return
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			c.printf("TearDown panicked: %v\n", r)
+			tb.Fail()
+		}
+	}()
+	c.ft.tearDown(c.ft)
+}
@@ -0,0 +1,86 @@
+package fun
+
+import "testing"
+
+func identity(n int) int { return n }
+
+func TestFixtures(t *testing.T) {
+	tf := &testFailer{}
+	f := test(tf, identity)
+
+	var log []string
+	f.SetUp(func(ft *FunTest) { log = append(log, "setup") })
+	f.TearDown(func(ft *FunTest) { log = append(log, "teardown") })
+
+	f.In(1).Out(1)
+	f.In(2).Out(2)
+
+	if tf.failed {
+		t.Fatal("fixtures shouldn't have caused a failure")
+	}
+	want := []string{"setup", "teardown", "setup", "teardown"}
+	if len(log) != len(want) {
+		t.Fatalf("log = %v, want %v", log, want)
+	}
+	for i := range want {
+		if log[i] != want[i] {
+			t.Fatalf("log = %v, want %v", log, want)
+		}
+	}
+}
+
+func TestFixturePanicFailsOnlyThatCase(t *testing.T) {
+	tf := &testFailer{}
+	f := test(tf, identity)
+
+	shouldPanic := true
+	f.SetUp(func(ft *FunTest) {
+		if shouldPanic {
+			panic("setup exploded")
+		}
+	})
+
+	f.In(1).Out(1)
+	if !tf.failed {
+		t.Error("case with panicking SetUp should have failed")
+	}
+	tf.failed = false
+
+	shouldPanic = false
+	f.In(2).Out(2)
+	if tf.failed {
+		t.Error("later case shouldn't be affected by the earlier panic")
+	}
+}
+
+func echo(s string) string { return s }
+
+// TestFromContextThreadsSetUpState checks that a fixture seeding
+// ft.Context (standing in for a temp directory's path) can reach the
+// function under test: a later In(...) call threads that value in via
+// FromContext, re-reading it fresh for every case.
+func TestFromContextThreadsSetUpState(t *testing.T) {
+	f := Test(t, echo)
+
+	f.SetUp(func(ft *FunTest) { ft.Context = "/tmp/fixture-1" })
+	f.In(FromContext(func(ctx interface{}) interface{} { return ctx })).Out("/tmp/fixture-1")
+
+	f.SetUp(func(ft *FunTest) { ft.Context = "/tmp/fixture-2" })
+	f.In(FromContext(func(ctx interface{}) interface{} { return ctx })).Out("/tmp/fixture-2")
+}
+
+func TestSetUpSuiteContext(t *testing.T) {
+	f := Test(t, identity)
+
+	f.SetUpSuite(func(ft *FunTest) { ft.Context = 42 })
+	f.TearDownSuite(func(ft *FunTest) {
+		if ft.Context != 42 {
+			t.Errorf("TearDownSuite saw Context = %v, want 42", ft.Context)
+		}
+	})
+
+	f.In(FromContext(func(ctx interface{}) interface{} { return ctx })).Out(42)
+	if f.Context != 42 {
+		t.Errorf("Context = %v, want 42", f.Context)
+	}
+}
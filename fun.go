@@ -48,9 +48,11 @@ Example:
 package fun
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 	"runtime"
+	"sync"
 	"testing"
 )
 
@@ -126,7 +128,7 @@ func test(t failer, fun interface{}) *FunTest {
 // It can either be called from the value returned by Test, or after Out/Err/Panic in a chain.
 func (ft *FunTest) In(args ...interface{}) Case {
 	ft.i++
-	return Case{ft, args}
+	return Case{ft: ft, args: args}
 }
 
 // Out is where you pass in the return variables that you expect.
@@ -141,54 +143,72 @@ func (c Case) Out(results ...interface{}) (ret *FunTest) {
 		return
 	}
 
-	defer func() {
-		if r := recover(); r != nil {
-			c.println("panic: ", r)
-			c.ft.t.Fail()
-		}
-	}()
+	c, results = c.extractComment(results)
 
-	argVals := make([]reflect.Value, len(c.args))
-	for i, arg := range c.args {
-		argVals[i] = reflect.ValueOf(arg)
-	}
-	resVals := c.ft.val.Call(argVals)
-	realResults := make([]interface{}, len(resVals))
-	for i, resVal := range resVals {
-		realResults[i] = resVal.Interface()
-	}
+	c.withSubtest(func(tb failer) {
+		defer func() {
+			if r := recover(); r != nil {
+				c.println("panic: ", r)
+				tb.Fail()
+			}
+		}()
 
-	if c.ft.errors && len(results) == len(realResults)-1 {
-		last := realResults[len(realResults)-1]
-		if last != nil {
-			c.println(last)
-			c.ft.t.Fail()
-			return
+		resVals := c.ft.val.Call(c.callArgs())
+		realResults := make([]interface{}, len(resVals))
+		for i, resVal := range resVals {
+			realResults[i] = resVal.Interface()
 		}
-		realResults = realResults[:len(realResults)-1]
-	}
 
-	if len(realResults) != len(results) {
-		c.printf("expected %d results, but got %d\n", len(results), len(realResults))
-		c.ft.t.Fail()
-		return
-	}
+		if c.ft.errors && len(results) == len(realResults)-1 {
+			last := realResults[len(realResults)-1]
+			if last != nil {
+				c.println(last)
+				tb.Fail()
+				return
+			}
+			realResults = realResults[:len(realResults)-1]
+		}
 
-	for i, rr := range realResults {
-		if !reflect.DeepEqual(rr, results[i]) {
-			c.printf("expected (%v), but got (%v)\n", results, realResults)
-			c.ft.t.Fail()
+		if len(realResults) != len(results) {
+			c.printf("expected %d results, but got %d\n", len(results), len(realResults))
+			tb.Fail()
 			return
 		}
-	}
+
+		for i, rr := range realResults {
+			if err := DeepEquals.Check(rr, []interface{}{results[i]}); err != nil {
+				c.printf("expected (%v), but got (%v)\n", results, realResults)
+				tb.Fail()
+				return
+			}
+		}
+	})
 
 	return
 }
 
+// errMatches reports whether got matches want the way Err expects: via
+// errors.Is, so that wrapped errors (fmt.Errorf("%w", ...) and similar)
+// compare against the sentinel error they wrap. For a want that
+// doesn't implement Unwrap, and so can't participate in error trees, it
+// falls back to reflect.DeepEqual, preserving the old behavior for
+// sentinel errors like errors.New("some message") that compare equal by
+// value but not by identity.
+func errMatches(got, want error) bool {
+	if errors.Is(got, want) {
+		return true
+	}
+	if _, ok := want.(interface{ Unwrap() error }); ok {
+		return false
+	}
+	return reflect.DeepEqual(got, want)
+}
+
 // Err should be called instead of Out if you just want to check for an error. This is only valid if the tested
 // function's final return value is an error.
 //
-// You can optionally pass in an error if you're expecting something specific.
+// You can optionally pass in an error if you're expecting something specific. Matching is done with errors.Is,
+// so wrapped errors are supported; use ErrAs if you need to inspect the matched error afterwards.
 func (c Case) Err(v ...interface{}) (ret *FunTest) {
 	ret = c.ft
 
@@ -196,91 +216,159 @@ func (c Case) Err(v ...interface{}) (ret *FunTest) {
 		return
 	}
 
-	if !c.ft.errors {
-		c.println("Err() called with a func that doesn't error")
-		c.ft.t.Fail()
-		return
-	}
+	c, v = c.extractComment(v)
 
-	defer func() {
-		if r := recover(); r != nil {
-			c.println("expected error, but panic occured:", r)
-			c.ft.t.Fail()
+	c.withSubtest(func(tb failer) {
+		if !c.ft.errors {
+			c.println("Err() called with a func that doesn't error")
+			tb.Fail()
+			return
 		}
-	}()
 
-	argVals := make([]reflect.Value, len(c.args))
-	for i, arg := range c.args {
-		argVals[i] = reflect.ValueOf(arg)
-	}
-	resVals := c.ft.val.Call(argVals)
+		defer func() {
+			if r := recover(); r != nil {
+				c.println("expected error, but panic occured:", r)
+				tb.Fail()
+			}
+		}()
 
-	if len(resVals) == 0 {
-		c.println("expected an error, but no values were returned")
-		c.ft.t.Fail()
-		return
-	}
+		resVals := c.ft.val.Call(c.callArgs())
 
-	last := resVals[len(resVals)-1].Interface()
-	err, ok := last.(error)
-	if err == nil {
-		if len(v) > 0 && v[0] == nil {
+		if len(resVals) == 0 {
+			c.println("expected an error, but no values were returned")
+			tb.Fail()
 			return
 		}
-		c.println("returned error was not nil")
-		c.ft.t.Fail()
-		return
-	}
-	if !ok {
-		c.println("last return value was not an error")
-		c.ft.t.Fail()
-		return
-	}
 
-	if len(v) > 0 && !reflect.DeepEqual(v[0], last) {
-		c.printf("wrong error: expected %v, but got %v\n", v[0], last)
-		c.ft.t.Fail()
-		return
-	}
+		last := resVals[len(resVals)-1].Interface()
+		err, ok := last.(error)
+		if err == nil {
+			if len(v) > 0 && v[0] == nil {
+				return
+			}
+			c.println("returned error was nil")
+			tb.Fail()
+			return
+		}
+		if !ok {
+			c.println("last return value was not an error")
+			tb.Fail()
+			return
+		}
+
+		if len(v) > 0 {
+			wantErr, wantIsErr := v[0].(error)
+			matched := wantIsErr && errMatches(err, wantErr)
+			if !wantIsErr {
+				matched = DeepEquals.Check(last, []interface{}{v[0]}) == nil
+			}
+			if !matched {
+				c.printf("wrong error: expected %v, but got %v\n", v[0], last)
+				tb.Fail()
+				return
+			}
+		}
+	})
 
 	return
 }
 
-// Panic should be called instead of Out if you want to check that a panic occured.
-//
-// You can optionally pass in a value if you're expecting something specific.
-func (c Case) Panic(v ...interface{}) (ret *FunTest) {
+// ErrAs requires errors.As(got, target) to succeed, where got is the
+// error returned by the tested function and target is a non-nil pointer,
+// as accepted by errors.As. On success target is populated, so the
+// caller can inspect the matched error after the chain returns.
+func (c Case) ErrAs(target interface{}) (ret *FunTest) {
 	ret = c.ft
 
 	if !c.ft.valid {
 		return
 	}
 
-	didPanic := true
+	c.withSubtest(func(tb failer) {
+		if !c.ft.errors {
+			c.println("ErrAs() called with a func that doesn't error")
+			tb.Fail()
+			return
+		}
+
+		defer func() {
+			if r := recover(); r != nil {
+				c.println("expected error, but panic occured:", r)
+				tb.Fail()
+			}
+		}()
+
+		resVals := c.ft.val.Call(c.callArgs())
 
-	defer func() {
-		if !didPanic {
+		if len(resVals) == 0 {
+			c.println("expected an error, but no values were returned")
+			tb.Fail()
 			return
 		}
-		r := recover()
-		if len(v) == 0 {
+
+		last := resVals[len(resVals)-1].Interface()
+		err, ok := last.(error)
+		if err == nil {
+			c.println("returned error was nil")
+			tb.Fail()
 			return
 		}
-		if !reflect.DeepEqual(v[0], r) {
-			c.printf("wrong panic value: expected %v, but got %v\n", v[0], r)
-			c.ft.t.Fail()
+		if !ok {
+			c.println("last return value was not an error")
+			tb.Fail()
+			return
 		}
-	}()
 
-	argVals := make([]reflect.Value, len(c.args))
-	for i, arg := range c.args {
-		argVals[i] = reflect.ValueOf(arg)
+		if !errors.As(err, target) {
+			c.printf("error %v could not be assigned via errors.As to %T\n", err, target)
+			tb.Fail()
+		}
+	})
+
+	return
+}
+
+// ErrContains is shorthand for checking that the returned error's
+// message contains substr.
+func (c Case) ErrContains(substr string) (ret *FunTest) {
+	return c.Check(errContains, substr)
+}
+
+// Panic should be called instead of Out if you want to check that a panic occured.
+//
+// You can optionally pass in a value if you're expecting something specific.
+func (c Case) Panic(v ...interface{}) (ret *FunTest) {
+	ret = c.ft
+
+	if !c.ft.valid {
+		return
 	}
-	c.ft.val.Call(argVals)
 
-	didPanic = false
-	c.println("function was called successfully, expected to panic")
-	c.ft.t.Fail()
+	c, v = c.extractComment(v)
+
+	c.withSubtest(func(tb failer) {
+		didPanic := true
+
+		defer func() {
+			if !didPanic {
+				return
+			}
+			r := recover()
+			if len(v) == 0 {
+				return
+			}
+			if err := DeepEquals.Check(r, []interface{}{v[0]}); err != nil {
+				c.printf("wrong panic value: expected %v, but got %v\n", v[0], r)
+				tb.Fail()
+			}
+		}()
+
+		c.ft.val.Call(c.callArgs())
+
+		didPanic = false
+		c.println("function was called successfully, expected to panic")
+		tb.Fail()
+	})
 
 	return
 }
@@ -294,20 +382,50 @@ type FunTest struct {
 	errors bool
 	name   string
 	i      int
+
+	// Context is free for SetUp/SetUpSuite fixtures to use to pass state
+	// (a temp directory, a handle, ...) to the cases that follow them.
+	// Since In(...)'s arguments are evaluated before that case's SetUp
+	// runs, reading Context directly in an In(...) call sees the value it
+	// held beforehand (its zero value, for the first case); use
+	// FromContext to defer that read until call time.
+	Context interface{}
+
+	setUp         func(ft *FunTest)
+	tearDown      func(ft *FunTest)
+	setUpSuite    func(ft *FunTest)
+	tearDownSuite func(ft *FunTest)
+	suiteOnce     sync.Once
 }
 
 // Case contains the Out/Err/Panic methods, and can be ignored as a type.
 type Case struct {
-	ft   *FunTest
-	args []interface{}
+	ft       *FunTest
+	args     []interface{}
+	name     string
+	parallel bool
+	comment  *Comment
+}
+
+func (c Case) callArgs() []reflect.Value {
+	argVals := make([]reflect.Value, len(c.args))
+	for i, arg := range c.args {
+		if ca, ok := arg.(contextArg); ok {
+			arg = ca.fn(c.ft.Context)
+		}
+		argVals[i] = reflect.ValueOf(arg)
+	}
+	return argVals
 }
 
 func (c Case) println(a ...interface{}) {
 	fmt.Printf("(%s) Case %d: ", c.ft.name, c.ft.i)
 	fmt.Println(a...)
+	c.printComment()
 }
 
 func (c Case) printf(format string, a ...interface{}) {
 	fmt.Printf("(%s) Case %d: ", c.ft.name, c.ft.i)
 	fmt.Printf(format, a...)
+	c.printComment()
 }
@@ -0,0 +1,64 @@
+package fun
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// Name overrides the auto-generated subtest name for this case. It has
+// no effect unless the underlying failer is a real *testing.T.
+func (c Case) Name(name string) Case {
+	c.name = name
+	return c
+}
+
+// Parallel marks this case as independent of the others, so its subtest
+// calls t.Parallel() and runs concurrently with other parallel subtests.
+// It has no effect unless the underlying failer is a real *testing.T.
+func (c Case) Parallel() Case {
+	c.parallel = true
+	return c
+}
+
+// caseName derives the subtest name for c: the Name() override if one
+// was given, otherwise the tested function's name plus a compact
+// rendering of the case's input arguments, e.g. "sumUnder10/case_3_in=1,2,3".
+func (c Case) caseName() string {
+	if c.name != "" {
+		return c.name
+	}
+	parts := make([]string, len(c.args))
+	for i, a := range c.args {
+		parts[i] = fmt.Sprint(a)
+	}
+	return fmt.Sprintf("%s/case_%d_in=%s", c.ft.name, c.ft.i, strings.Join(parts, ","))
+}
+
+// withSubtest runs body inside a t.Run subtest when the FunTest's failer
+// is a real *testing.T, so failures are reported per-case, go test -run
+// can target a single case, and -v output shows one line per case. For
+// any other failer, body runs immediately against it, preserving the
+// behavior Out/Err/Panic had before subtests were introduced.
+func (c Case) withSubtest(body func(tb failer)) {
+	run := func(tb failer) {
+		c.ft.runSetUpSuite()
+		if !c.runSetUp(tb) {
+			return
+		}
+		defer c.runTearDown(tb)
+		body(tb)
+	}
+
+	t, ok := c.ft.t.(*testing.T)
+	if !ok {
+		run(c.ft.t)
+		return
+	}
+	t.Run(c.caseName(), func(st *testing.T) {
+		if c.parallel {
+			st.Parallel()
+		}
+		run(st)
+	})
+}
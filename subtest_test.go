@@ -0,0 +1,27 @@
+package fun
+
+import "testing"
+
+func addTwo(a, b int) int { return a + b }
+
+func TestCaseName(t *testing.T) {
+	tf := &testFailer{}
+	f := test(tf, addTwo)
+
+	c := f.In(1, 2)
+	if got, want := c.caseName(), "addTwo/case_1_in=1,2"; got != want {
+		t.Errorf("caseName() = %q, want %q", got, want)
+	}
+
+	named := c.Name("custom-name")
+	if got, want := named.caseName(), "custom-name"; got != want {
+		t.Errorf("caseName() with override = %q, want %q", got, want)
+	}
+}
+
+func TestSubtests(t *testing.T) {
+	f := Test(t, addTwo)
+	f.In(1, 2).Out(3)
+	f.In(2, 3).Name("two-plus-three").Out(5)
+	f.In(4, 5).Parallel().Out(9)
+}